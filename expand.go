@@ -0,0 +1,106 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+)
+
+// expandShell runs shell-style variable expansion over value, resolving
+// references via get (falling back to "" for a nil get, same as an unset
+// variable). Supports ${VAR}, $VAR, ${VAR:-fallback}, ${VAR:?message} and
+// $$ as an escape for a literal $.
+func expandShell(value string, get func(string) string) (string, error) {
+	if get == nil {
+		get = func(string) string { return "" }
+	}
+
+	var out strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		if c != '$' {
+			out.WriteByte(c)
+			continue
+		}
+
+		// last character is a lone "$"
+		if i+1 >= len(value) {
+			out.WriteByte(c)
+			continue
+		}
+
+		next := value[i+1]
+
+		if next == '$' {
+			out.WriteByte('$')
+			i++
+			continue
+		}
+
+		if next == '{' {
+			end := strings.IndexByte(value[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf(`env: expand: unterminated "${" in %q`, value)
+			}
+			end += i + 2
+
+			resolved, err := expandBraced(value[i+2:end], get)
+			if err != nil {
+				return "", err
+			}
+
+			out.WriteString(resolved)
+			i = end
+			continue
+		}
+
+		if isShellVarStart(next) {
+			j := i + 1
+			for j < len(value) && isShellVarPart(value[j]) {
+				j++
+			}
+			out.WriteString(get(value[i+1 : j]))
+			i = j - 1
+			continue
+		}
+
+		out.WriteByte(c)
+	}
+
+	return out.String(), nil
+}
+
+// expandBraced resolves the inside of a "${...}" reference, handling the
+// plain "NAME", ":-fallback" and ":?message" forms.
+func expandBraced(expr string, get func(string) string) (string, error) {
+	if idx := strings.Index(expr, ":-"); idx >= 0 {
+		name, fallback := expr[:idx], expr[idx+2:]
+		if v := get(name); v != "" {
+			return v, nil
+		}
+		return fallback, nil
+	}
+
+	if idx := strings.Index(expr, ":?"); idx >= 0 {
+		name, message := expr[:idx], expr[idx+2:]
+		v := get(name)
+		if v == "" {
+			if message == "" {
+				message = "not set"
+			}
+			return "", fmt.Errorf("env: %s: %s", name, message)
+		}
+		return v, nil
+	}
+
+	return get(expr), nil
+}
+
+func isShellVarStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isShellVarPart(c byte) bool {
+	return isShellVarStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,65 @@
+package env
+
+import "testing"
+
+func TestExpandShell(t *testing.T) {
+	get := func(key string) string {
+		switch key {
+		case "USER":
+			return "alice"
+		case "EMPTY":
+			return ""
+		default:
+			return ""
+		}
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "braced var", value: "hello ${USER}", want: "hello alice"},
+		{name: "bare var", value: "hello $USER", want: "hello alice"},
+		{name: "literal dollar-dollar", value: "cost is $$5", want: "cost is $5"},
+		{name: "fallback used when unset", value: "${NOPE:-fallback}", want: "fallback"},
+		{name: "fallback used when empty", value: "${EMPTY:-fallback}", want: "fallback"},
+		{name: "fallback skipped when set", value: "${USER:-fallback}", want: "alice"},
+		{name: "required message on unset", value: "${NOPE:?must be set}", wantErr: true},
+		{name: "required satisfied when set", value: "${USER:?must be set}", want: "alice"},
+		{name: "trailing lone dollar is literal", value: "a$", want: "a$"},
+		{name: "unterminated brace errors", value: "${USER", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandShell(tt.value, get)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expandShell(%q) expected error, got none", tt.value)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expandShell(%q) unexpected error: %v", tt.value, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expandShell(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandShellNilGet(t *testing.T) {
+	got, err := expandShell("${NOPE:-fallback}", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "fallback" {
+		t.Errorf("expandShell with nil get = %q, want %q", got, "fallback")
+	}
+}
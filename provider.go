@@ -0,0 +1,424 @@
+package env
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	// YAML not included in golang encode package
+	"gopkg.in/yaml.v2"
+)
+
+// Provider resolves the string value for a key. An empty string means the
+// key is unset, matching the convention used by NewVarWithFunc's get func.
+type Provider interface {
+	Get(key string) string
+}
+
+// ProviderFunc adapts a plain function to the Provider interface.
+type ProviderFunc func(key string) string
+
+// Get implements Provider.
+func (f ProviderFunc) Get(key string) string {
+	return f(key)
+}
+
+// osProvider reads from the process environment.
+type osProvider struct{}
+
+// NewOSProvider returns a Provider backed by os.Getenv.
+func NewOSProvider() Provider {
+	return osProvider{}
+}
+
+// Get implements Provider.
+func (osProvider) Get(key string) string {
+	return os.Getenv(key)
+}
+
+// mapProvider serves values out of a map re-parsed from a backing file.
+// Reads and refreshes are synchronized so a Watcher-driven Refresh doesn't
+// race a concurrent Get.
+type mapProvider struct {
+	mu     sync.RWMutex
+	values map[string]string
+	path   string
+	loader func(path string) (map[string]string, error)
+}
+
+// Get implements Provider.
+func (p *mapProvider) Get(key string) string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.values[strings.ToUpper(key)]
+}
+
+// Path implements pathProvider, so a Watcher can fsnotify this provider's
+// backing file.
+func (p *mapProvider) Path() string {
+	return p.path
+}
+
+// Refresh implements refreshableProvider by re-parsing the backing file, so
+// a Watcher reload actually picks up on-disk changes instead of replaying
+// the map captured at construction time.
+func (p *mapProvider) Refresh() error {
+	values, err := p.loader(p.path)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.values = values
+	p.mu.Unlock()
+
+	return nil
+}
+
+// chainProvider tries each Provider in order and returns the first
+// non-empty value.
+type chainProvider struct {
+	providers []Provider
+}
+
+// Chain returns a Provider that resolves a key by trying each of the given
+// providers in order, first non-empty value wins.
+func Chain(providers ...Provider) Provider {
+	return chainProvider{providers: providers}
+}
+
+// Get implements Provider.
+func (c chainProvider) Get(key string) string {
+	for _, p := range c.providers {
+		if p == nil {
+			continue
+		}
+		if value := p.Get(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// NewDotenvProvider parses a dotenv file and returns a Provider serving its
+// keys. Supports `KEY=value`, `export KEY=value`, single- and double-quoted
+// values (with \n, \t, \" escapes only inside double quotes), `#` comments
+// outside of quotes (including trailing a quoted value), blank lines, and
+// ${OTHER} / $OTHER expansion against previously-loaded keys and the OS
+// environment. Single-quoted values are literal and are never expanded,
+// matching standard dotenv semantics.
+func NewDotenvProvider(path string) (Provider, error) {
+	values, err := parseDotenvFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mapProvider{values: values, path: path, loader: parseDotenvFile}, nil
+}
+
+// parseDotenvFile parses a dotenv file into its resolved key/value map. It
+// is also used as mapProvider's loader, so a Watcher's Reload re-reads the
+// file from disk rather than replaying a stale in-memory snapshot.
+func parseDotenvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		line = strings.TrimSpace(line)
+
+		idx := strings.Index(line, "=")
+		if idx < 0 {
+			continue
+		}
+
+		key := strings.ToUpper(strings.TrimSpace(line[:idx]))
+		rawValue := strings.TrimSpace(line[idx+1:])
+
+		value, singleQuoted, err := parseDotenvValue(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("env: dotenv %s: %w", path, err)
+		}
+
+		if singleQuoted {
+			values[key] = value
+		} else {
+			values[key] = expandDotenvValue(value, values)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// parseDotenvValue strips a single layer of quoting from a raw dotenv value,
+// processes escapes for double-quoted values, and reports whether the value
+// was single-quoted (which suppresses ${OTHER} expansion). Anything after
+// the closing quote (e.g. a trailing `# comment`) is discarded; unquoted
+// values are truncated at the first `#` to support the same trailing
+// comments.
+func parseDotenvValue(raw string) (value string, singleQuoted bool, err error) {
+	if raw == "" {
+		return "", false, nil
+	}
+
+	switch raw[0] {
+	case '"':
+		end := closingQuoteIndex(raw, '"')
+		if end < 0 {
+			return "", false, fmt.Errorf(`unterminated " in value %s`, raw)
+		}
+		replacer := strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`)
+		return replacer.Replace(raw[1:end]), false, nil
+	case '\'':
+		end := closingQuoteIndex(raw, '\'')
+		if end < 0 {
+			return "", false, fmt.Errorf(`unterminated ' in value %s`, raw)
+		}
+		return raw[1:end], true, nil
+	}
+
+	if idx := strings.Index(raw, "#"); idx >= 0 {
+		raw = strings.TrimSpace(raw[:idx])
+	}
+
+	return raw, false, nil
+}
+
+// closingQuoteIndex returns the index of the unescaped quote byte that
+// closes the quoted value starting at raw[0], or -1 if raw has no closing
+// quote.
+func closingQuoteIndex(raw string, quote byte) int {
+	for i := 1; i < len(raw); i++ {
+		if raw[i] == '\\' {
+			i++
+			continue
+		}
+		if raw[i] == quote {
+			return i
+		}
+	}
+	return -1
+}
+
+// expandDotenvValue resolves ${OTHER} / $OTHER references against keys
+// loaded so far, falling back to the OS environment.
+func expandDotenvValue(value string, loaded map[string]string) string {
+	return os.Expand(value, func(name string) string {
+		if v, ok := loaded[strings.ToUpper(name)]; ok {
+			return v
+		}
+		return os.Getenv(name)
+	})
+}
+
+// NewYAMLProvider parses a YAML config file and returns a Provider serving
+// its keys. Nested maps are flattened with "_" and upper-cased, so
+// `db: {host: x}` becomes key DB_HOST.
+func NewYAMLProvider(path string) (Provider, error) {
+	values, err := parseYAMLFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mapProvider{values: values, path: path, loader: parseYAMLFile}, nil
+}
+
+// parseYAMLFile parses a YAML config file into its flattened key/value map.
+// It also serves as mapProvider's loader for this provider.
+func parseYAMLFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("env: yaml %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	flattenYaml("", raw, values)
+
+	return values, nil
+}
+
+func flattenYaml(prefix string, in map[string]interface{}, out map[string]string) {
+	for k, v := range in {
+		key := strings.ToUpper(k)
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+
+		switch val := v.(type) {
+		case map[interface{}]interface{}:
+			nested := make(map[string]interface{}, len(val))
+			for nk, nv := range val {
+				nested[fmt.Sprintf("%v", nk)] = nv
+			}
+			flattenYaml(key, nested, out)
+		case map[string]interface{}:
+			flattenYaml(key, val, out)
+		default:
+			out[key] = fmt.Sprintf("%v", val)
+		}
+	}
+}
+
+// NewJSONProvider parses a JSON config file and returns a Provider serving
+// its keys. Nested objects are flattened the same way as NewYAMLProvider.
+func NewJSONProvider(path string) (Provider, error) {
+	values, err := parseJSONFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mapProvider{values: values, path: path, loader: parseJSONFile}, nil
+}
+
+// parseJSONFile parses a JSON config file into its flattened key/value map.
+// It also serves as mapProvider's loader for this provider.
+func parseJSONFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("env: json %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	flattenYaml("", raw, values)
+
+	return values, nil
+}
+
+// Load walks the fields of cfg (a pointer to a struct), resolves each one
+// against the given providers (first non-empty wins, same precedence as
+// Chain) and populates the struct in one call. With no providers, it falls
+// back to the OS environment.
+func Load(cfg interface{}, providers ...Provider) error {
+	if len(providers) == 0 {
+		providers = []Provider{NewOSProvider()}
+	}
+
+	chain := Chain(providers...)
+
+	ptr := reflect.ValueOf(cfg)
+	if ptr.Kind() != reflect.Ptr || ptr.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("env: Load requires a pointer to a struct")
+	}
+
+	errs := &ValidationError{}
+	loadStruct(ptr.Elem(), "", chain, errs)
+
+	if errs.HasErrors() {
+		return errs
+	}
+
+	return nil
+}
+
+var timeTimeType = reflect.TypeOf(time.Time{})
+
+// nestedKeyPrefix composes the env key prefix for a nested struct field:
+// keyPrefix + the field's own name (e.g. "" + "DB_" for a field named DB
+// gives "DB_", so Config.DB.Host resolves DB_HOST), unless overridden by an
+// `env:"prefix=..."` tag on the field.
+func nestedKeyPrefix(keyPrefix string, field reflect.StructField, params map[string]string) string {
+	if prefix, ok := params["prefix"]; ok {
+		return prefix
+	}
+	return keyPrefix + strings.ToUpper(field.Name) + "_"
+}
+
+// loadStruct recursively walks structValue's fields, composing env keys
+// from keyPrefix + field name (or an `env:"prefix=..."` override on struct
+// fields), and resolves each leaf field against get. Every field is
+// attempted even if an earlier one failed; failures are appended to errs
+// so Load can report every misconfigured field at once.
+func loadStruct(structValue reflect.Value, keyPrefix string, get Provider, errs *ValidationError) {
+	structType := structValue.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldValue := structValue.Field(i)
+
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		params := parseTagParams(field.Tag.Get("env"))
+
+		fieldType := field.Type
+		isPtr := fieldType.Kind() == reflect.Ptr
+		if isPtr {
+			fieldType = fieldType.Elem()
+		}
+
+		// Nested struct fields (but not a struct with its own decode
+		// strategy, e.g. decode=yaml into a struct) recurse instead of
+		// being resolved as a single Var.
+		if fieldType.Kind() == reflect.Struct && fieldType != timeTimeType && params["decode"] == "" {
+			childPrefix := nestedKeyPrefix(keyPrefix, field, params)
+
+			if isPtr {
+				if fieldValue.IsNil() {
+					fieldValue.Set(reflect.New(fieldType))
+				}
+				loadStruct(fieldValue.Elem(), childPrefix, get, errs)
+			} else {
+				loadStruct(fieldValue, childPrefix, get, errs)
+			}
+
+			continue
+		}
+
+		scalarField := field
+		scalarField.Type = fieldType
+
+		newVar, err := NewVarWithFunc(scalarField, func(key string) string {
+			return get.Get(keyPrefix + key)
+		})
+		if err != nil {
+			if fieldErrs, ok := err.(*ValidationError); ok {
+				for _, fe := range fieldErrs.Errors {
+					errs.Add(keyPrefix+fe.Key, fe.Err)
+				}
+			} else {
+				errs.Add(keyPrefix+newVar.Key, err)
+			}
+			continue
+		}
+
+		if isPtr {
+			ptr := reflect.New(fieldType)
+			ptr.Elem().Set(newVar.Value)
+			fieldValue.Set(ptr)
+		} else {
+			fieldValue.Set(newVar.Value)
+		}
+	}
+}
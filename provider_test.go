@@ -0,0 +1,74 @@
+package env
+
+import "testing"
+
+func TestParseDotenvValue(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        string
+		wantValue  string
+		wantQuoted bool
+		wantErr    bool
+	}{
+		{name: "unquoted", raw: "bar", wantValue: "bar"},
+		{name: "unquoted with trailing comment", raw: `bar # a comment`, wantValue: "bar"},
+		{name: "double quoted", raw: `"bar"`, wantValue: "bar"},
+		{name: "double quoted with trailing comment", raw: `"bar" # a comment`, wantValue: "bar"},
+		{name: "double quoted escapes", raw: `"line1\nline2\t\"quoted\""`, wantValue: "line1\nline2\t\"quoted\""},
+		{name: "double quoted preserves $", raw: `"${HOME}"`, wantValue: "${HOME}"},
+		{name: "single quoted is literal", raw: `'$HOME'`, wantValue: "$HOME", wantQuoted: true},
+		{name: "single quoted with trailing comment", raw: `'literal' # note`, wantValue: "literal", wantQuoted: true},
+		{name: "unterminated double quote", raw: `"bar`, wantErr: true},
+		{name: "unterminated single quote", raw: `'bar`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, quoted, err := parseDotenvValue(tt.raw)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseDotenvValue(%q) expected error, got none", tt.raw)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parseDotenvValue(%q) unexpected error: %v", tt.raw, err)
+			}
+
+			if value != tt.wantValue {
+				t.Errorf("parseDotenvValue(%q) value = %q, want %q", tt.raw, value, tt.wantValue)
+			}
+
+			if quoted != tt.wantQuoted {
+				t.Errorf("parseDotenvValue(%q) singleQuoted = %v, want %v", tt.raw, quoted, tt.wantQuoted)
+			}
+		})
+	}
+}
+
+func TestExpandDotenvValue(t *testing.T) {
+	loaded := map[string]string{"HOST": "localhost"}
+	t.Setenv("ENV_TEST_FALLBACK", "from-os")
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "braced reference to loaded key", value: "${HOST}:5432", want: "localhost:5432"},
+		{name: "bare reference to loaded key", value: "$HOST", want: "localhost"},
+		{name: "falls back to OS environment", value: "${ENV_TEST_FALLBACK}", want: "from-os"},
+		{name: "unset reference expands empty", value: "${NOPE}", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandDotenvValue(tt.value, loaded)
+			if got != tt.want {
+				t.Errorf("expandDotenvValue(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
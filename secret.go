@@ -0,0 +1,87 @@
+package env
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// SecretProvider resolves a secret reference URI (e.g.
+// "vault://secret/data/app#password") into its plaintext value.
+type SecretProvider interface {
+	Resolve(uri string) (string, error)
+}
+
+var (
+	secretProvidersMu sync.RWMutex
+	secretProviders   = map[string]SecretProvider{
+		"file": fileSecretProvider{},
+	}
+)
+
+// RegisterSecretProvider registers a SecretProvider for the given URI
+// scheme (the part before "://"). Cloud providers (Vault, AWS/GCP secrets
+// manager, ...) are expected to live in optional subpackages that call
+// this at init time; only the file:// provider ships in this package.
+func RegisterSecretProvider(scheme string, p SecretProvider) {
+	secretProvidersMu.Lock()
+	defer secretProvidersMu.Unlock()
+	secretProviders[scheme] = p
+}
+
+// resolveSecret looks up the provider registered for uri's scheme and
+// resolves it.
+func resolveSecret(uri string) (string, error) {
+	scheme, _, ok := strings.Cut(uri, "://")
+	if !ok {
+		return "", fmt.Errorf("env: secret: %q is not a scheme://... reference", uri)
+	}
+
+	secretProvidersMu.RLock()
+	p, ok := secretProviders[scheme]
+	secretProvidersMu.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("env: secret: no provider registered for scheme %q", scheme)
+	}
+
+	return p.Resolve(uri)
+}
+
+// fileSecretProvider resolves file:///path/to/secret references by
+// reading the file's contents.
+type fileSecretProvider struct{}
+
+// Resolve implements SecretProvider.
+func (fileSecretProvider) Resolve(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("env: secret: %q: %w", uri, err)
+	}
+
+	data, err := os.ReadFile(u.Path)
+	if err != nil {
+		return "", fmt.Errorf("env: secret: %q: %w", uri, err)
+	}
+
+	return strings.TrimRight(string(data), "\n"), nil
+}
+
+// resolveFileConvention implements the `FOO_FILE` convention commonly used
+// with Docker/Kubernetes secrets: if key is unset but key+"_FILE" is set,
+// its contents are read and used as the value for key.
+func resolveFileConvention(key string, get func(string) string) (string, string, error) {
+	path := get(key + "_FILE")
+	if path == "" {
+		return "", "", nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf("env: %s_FILE: %w", key, err)
+	}
+
+	return strings.TrimRight(string(data), "\n"), path, nil
+}
@@ -0,0 +1,180 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// Usage writes a formatted table of every env key driven by cfg's struct
+// fields: type, required flag, default, allowed options and a `desc:"..."`
+// tag, if present. Gives operators a discoverable `myapp env-help` UX.
+func Usage(w io.Writer, cfg interface{}) error {
+	structValue, err := configStructValue(cfg)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tTYPE\tREQUIRED\tDEFAULT\tOPTIONS\tDESCRIPTION")
+
+	if err := usageFields(tw, structValue, ""); err != nil {
+		return err
+	}
+
+	return tw.Flush()
+}
+
+func usageFields(tw *tabwriter.Writer, structValue reflect.Value, keyPrefix string) error {
+	structType := structValue.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		params := parseTagParams(field.Tag.Get("env"))
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType.Kind() == reflect.Struct && fieldType != timeTimeType && params["decode"] == "" {
+			childPrefix := nestedKeyPrefix(keyPrefix, field, params)
+
+			if err := usageFields(tw, reflect.New(fieldType).Elem(), childPrefix); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		scalarField := field
+		scalarField.Type = fieldType
+
+		newVar := &Var{}
+		if err := newVar.Parse(scalarField); err != nil {
+			return err
+		}
+
+		def := "-"
+		if newVar.Default != reflect.ValueOf(nil) {
+			def = fmt.Sprintf("%v", newVar.Default.Interface())
+		}
+
+		opts := "-"
+		if len(newVar.Options) > 0 {
+			parts := make([]string, len(newVar.Options))
+			for i, o := range newVar.Options {
+				parts[i] = fmt.Sprintf("%v", o.Interface())
+			}
+			opts = strings.Join(parts, ",")
+		}
+
+		required := ""
+		if newVar.Required {
+			required = "yes"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			keyPrefix+newVar.Key, fieldType.String(), required, def, opts, newVar.Desc)
+	}
+
+	return nil
+}
+
+// Dump writes the resolved KEY=value pairs of a populated cfg, redacting
+// any field tagged `env:"... sensitive"` as "***".
+func Dump(w io.Writer, cfg interface{}) error {
+	structValue, err := configStructValue(cfg)
+	if err != nil {
+		return err
+	}
+
+	return dumpFields(w, structValue, "")
+}
+
+func dumpFields(w io.Writer, structValue reflect.Value, keyPrefix string) error {
+	structType := structValue.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldValue := structValue.Field(i)
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		params := parseTagParams(field.Tag.Get("env"))
+
+		fieldType := field.Type
+		isPtr := fieldType.Kind() == reflect.Ptr
+		if isPtr {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType.Kind() == reflect.Struct && fieldType != timeTimeType && params["decode"] == "" {
+			childPrefix := nestedKeyPrefix(keyPrefix, field, params)
+
+			nested := fieldValue
+			if isPtr {
+				if fieldValue.IsNil() {
+					continue
+				}
+				nested = fieldValue.Elem()
+			}
+
+			if err := dumpFields(w, nested, childPrefix); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		scalarField := field
+		scalarField.Type = fieldType
+
+		newVar := &Var{}
+		if err := newVar.Parse(scalarField); err != nil {
+			return err
+		}
+
+		value := fieldValue
+		if isPtr {
+			if fieldValue.IsNil() {
+				fmt.Fprintf(w, "%s=\n", keyPrefix+newVar.Key)
+				continue
+			}
+			value = fieldValue.Elem()
+		}
+
+		display := fmt.Sprintf("%v", value.Interface())
+		if newVar.Sensitive {
+			display = "***"
+		}
+
+		fmt.Fprintf(w, "%s=%s\n", keyPrefix+newVar.Key, display)
+	}
+
+	return nil
+}
+
+// configStructValue unwraps cfg (a struct or pointer to struct) into its
+// addressable-or-not reflect.Value, rejecting anything else.
+func configStructValue(cfg interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("env: expected a struct or pointer to struct, got %T", cfg)
+	}
+
+	return v, nil
+}
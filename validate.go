@@ -0,0 +1,194 @@
+package env
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FieldError is a single field's validation failure, keyed by its env key.
+type FieldError struct {
+	Key string
+	Err error
+}
+
+// ValidationError aggregates every field that failed validation so callers
+// can fix all misconfiguration in one pass instead of iterating.
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+// Add appends a field failure. A nil err is a no-op.
+func (e *ValidationError) Add(key string, err error) {
+	if err == nil {
+		return
+	}
+	e.Errors = append(e.Errors, &FieldError{Key: key, Err: err})
+}
+
+// HasErrors reports whether any field failed.
+func (e *ValidationError) HasErrors() bool {
+	return e != nil && len(e.Errors) > 0
+}
+
+// Error implements error, stringifying as a multi-line report grouped by
+// env key.
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Errors)+1)
+	lines = append(lines, fmt.Sprintf("env: %d configuration error(s):", len(e.Errors)))
+	for _, fe := range e.Errors {
+		lines = append(lines, fmt.Sprintf("  %s: %s", fe.Key, fe.Err))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// validatorSpec is one "name=param" (or bare "name") entry from a
+// `validate=...` tag.
+type validatorSpec struct {
+	Name  string
+	Param string
+}
+
+// parseValidateTag splits a `validate=min=1,max=65535` tag value into its
+// individual specs.
+func parseValidateTag(value string) []validatorSpec {
+	if value == "" {
+		return nil
+	}
+
+	parts := strings.Split(value, ",")
+	specs := make([]validatorSpec, 0, len(parts))
+
+	for _, part := range parts {
+		name, param, _ := strings.Cut(part, "=")
+		specs = append(specs, validatorSpec{Name: name, Param: param})
+	}
+
+	return specs
+}
+
+var (
+	validatorsMu sync.RWMutex
+	validators   = map[string]func(reflect.Value, string) error{
+		"min":      validateMin,
+		"max":      validateMax,
+		"regexp":   validateRegexp,
+		"oneof":    validateOneof,
+		"url":      validateURL,
+		"hostport": validateHostport,
+	}
+)
+
+// RegisterValidator registers a user-defined `validate=name=param` rule.
+func RegisterValidator(name string, fn func(reflect.Value, string) error) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	validators[name] = fn
+}
+
+// runValidators runs every spec against value, returning the first failure.
+func runValidators(value reflect.Value, specs []validatorSpec) error {
+	for _, spec := range specs {
+		validatorsMu.RLock()
+		fn, ok := validators[spec.Name]
+		validatorsMu.RUnlock()
+
+		if !ok {
+			return fmt.Errorf("env: no validator registered for %q", spec.Name)
+		}
+
+		if err := fn(value, spec.Param); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateMin(value reflect.Value, param string) error {
+	min, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return fmt.Errorf("validate=min: invalid param %q", param)
+	}
+
+	if value.Kind() != reflect.Int {
+		return fmt.Errorf("validate=min: unsupported kind %s", value.Kind())
+	}
+
+	if value.Int() < min {
+		return fmt.Errorf("must be >= %d, got %d", min, value.Int())
+	}
+
+	return nil
+}
+
+func validateMax(value reflect.Value, param string) error {
+	max, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return fmt.Errorf("validate=max: invalid param %q", param)
+	}
+
+	if value.Kind() != reflect.Int {
+		return fmt.Errorf("validate=max: unsupported kind %s", value.Kind())
+	}
+
+	if value.Int() > max {
+		return fmt.Errorf("must be <= %d, got %d", max, value.Int())
+	}
+
+	return nil
+}
+
+func validateRegexp(value reflect.Value, param string) error {
+	re, err := regexp.Compile(param)
+	if err != nil {
+		return fmt.Errorf("validate=regexp: invalid pattern %q: %w", param, err)
+	}
+
+	if !re.MatchString(fmt.Sprintf("%v", value.Interface())) {
+		return fmt.Errorf("must match %s", param)
+	}
+
+	return nil
+}
+
+// validateOneof is a superset of the `options` tag: same semantics, but
+// expressed as `validate=oneof=a|b|c` alongside other validators.
+func validateOneof(value reflect.Value, param string) error {
+	allowed := strings.Split(param, "|")
+	actual := fmt.Sprintf("%v", value.Interface())
+
+	for _, a := range allowed {
+		if a == actual {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("must be one of %s, got %q", param, actual)
+}
+
+func validateURL(value reflect.Value, _ string) error {
+	raw := fmt.Sprintf("%v", value.Interface())
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("must be a valid absolute URL, got %q", raw)
+	}
+
+	return nil
+}
+
+func validateHostport(value reflect.Value, _ string) error {
+	raw := fmt.Sprintf("%v", value.Interface())
+
+	if _, _, err := net.SplitHostPort(raw); err != nil {
+		return fmt.Errorf("must be a valid host:port, got %q", raw)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package env
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidators(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func(reflect.Value, string) error
+		value   interface{}
+		param   string
+		wantErr bool
+	}{
+		{name: "min ok", fn: validateMin, value: 10, param: "1", wantErr: false},
+		{name: "min too small", fn: validateMin, value: 0, param: "1", wantErr: true},
+		{name: "min unsupported kind", fn: validateMin, value: "nope", param: "1", wantErr: true},
+		{name: "max ok", fn: validateMax, value: 100, param: "65535", wantErr: false},
+		{name: "max too big", fn: validateMax, value: 70000, param: "65535", wantErr: true},
+		{name: "regexp matches", fn: validateRegexp, value: "prod-1", param: "^[a-z][a-z0-9-]*$", wantErr: false},
+		{name: "regexp does not match", fn: validateRegexp, value: "PROD", param: "^[a-z][a-z0-9-]*$", wantErr: true},
+		{name: "oneof matches", fn: validateOneof, value: "stage", param: "dev|stage|prod", wantErr: false},
+		{name: "oneof does not match", fn: validateOneof, value: "qa", param: "dev|stage|prod", wantErr: true},
+		{name: "url valid", fn: validateURL, value: "https://example.com/path", wantErr: false},
+		{name: "url invalid", fn: validateURL, value: "not-a-url", wantErr: true},
+		{name: "hostport valid", fn: validateHostport, value: "localhost:5432", wantErr: false},
+		{name: "hostport invalid", fn: validateHostport, value: "localhost", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fn(reflect.ValueOf(tt.value), tt.param)
+
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected error, got none")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestParseValidateTag(t *testing.T) {
+	specs := parseValidateTag("min=1,max=65535,oneof=dev|stage|prod")
+
+	want := []validatorSpec{
+		{Name: "min", Param: "1"},
+		{Name: "max", Param: "65535"},
+		{Name: "oneof", Param: "dev|stage|prod"},
+	}
+
+	if !reflect.DeepEqual(specs, want) {
+		t.Fatalf("parseValidateTag() = %+v, want %+v", specs, want)
+	}
+}
+
+func TestRunValidatorsReturnsFailure(t *testing.T) {
+	specs := parseValidateTag("min=10")
+
+	if err := runValidators(reflect.ValueOf(1), specs); err == nil {
+		t.Fatal("expected error from runValidators, got none")
+	}
+
+	if err := runValidators(reflect.ValueOf(20), specs); err != nil {
+		t.Fatalf("unexpected error from runValidators: %v", err)
+	}
+}
+
+func TestValidationErrorString(t *testing.T) {
+	errs := &ValidationError{}
+	if errs.HasErrors() {
+		t.Fatal("empty ValidationError should report HasErrors() == false")
+	}
+
+	errs.Add("PORT", errFromString("must be >= 1"))
+	errs.Add("ENV", errFromString(`must be one of dev|stage|prod`))
+
+	if !errs.HasErrors() {
+		t.Fatal("expected HasErrors() == true after Add")
+	}
+
+	if len(errs.Errors) != 2 {
+		t.Fatalf("expected 2 aggregated errors, got %d", len(errs.Errors))
+	}
+
+	want := "env: 2 configuration error(s):\n  PORT: must be >= 1\n  ENV: must be one of dev|stage|prod"
+	if got := errs.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+type stringError string
+
+func (e stringError) Error() string { return string(e) }
+
+func errFromString(s string) error { return stringError(s) }
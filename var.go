@@ -22,6 +22,18 @@ type Var struct {
 	Decode      string
 	Default     reflect.Value
 	Options     []reflect.Value
+	Separator   string
+	NotEmpty    bool
+	Prefix      string
+	Validators  []validatorSpec
+	Sensitive   bool
+	Desc        string
+	Reloadable  bool
+
+	// get resolves the raw string value for a key; used directly for the
+	// field's own value and threaded into convert() for decode strategies
+	// (e.g. "expand") that resolve other keys.
+	get func(string) string
 }
 
 // NewVar returns a new Var
@@ -32,13 +44,27 @@ func NewVar(field reflect.StructField) (*Var, error) {
 // NewVarWithFunc returns a new Var. get returns the value for the given key
 func NewVarWithFunc(field reflect.StructField, get func(string) string) (*Var, error) {
 	// spew.Dump(new(Var).Default == reflect.ValueOf(nil))
-	newVar := &Var{} //Default: reflect.ValueOf(nil)}
+	newVar := &Var{get: get} //Default: reflect.ValueOf(nil)}
 	newVar.Parse(field)
 
 	var value reflect.Value
 	var err error
 
-	value, err = convert(newVar.Type, get(newVar.Key), newVar.Decode)
+	raw := get(newVar.Key)
+
+	if raw == "" {
+		fileValue, _, ferr := resolveFileConvention(newVar.Key, get)
+		if ferr != nil {
+			return newVar, ferr
+		}
+		raw = fileValue
+	}
+
+	if newVar.Separator != "" && (newVar.Type.Kind() == reflect.Slice || newVar.Type.Kind() == reflect.Map) {
+		value, err = convertWithSeparator(newVar.Type, raw, newVar.Separator)
+	} else {
+		value, err = convert(newVar.Type, raw, newVar.Decode, newVar.get)
+	}
 
 	if err != nil {
 		return newVar, err
@@ -46,9 +72,11 @@ func NewVarWithFunc(field reflect.StructField, get func(string) string) (*Var, e
 
 	newVar.SetValue(value)
 
+	fieldErrs := &ValidationError{}
+
 	if value == reflect.ValueOf(nil) {
 		if newVar.Required {
-			return newVar, fmt.Errorf("%s required", newVar.Key)
+			fieldErrs.Add(newVar.Key, fmt.Errorf("%s required", newVar.Key))
 		}
 
 		// Check if we have a default value to set, otherwise set the type's zero value
@@ -61,12 +89,26 @@ func NewVarWithFunc(field reflect.StructField, get func(string) string) (*Var, e
 		}
 	}
 
+	if newVar.NotEmpty && newVar.Type.Kind() == reflect.String && newVar.Value.String() == "" {
+		fieldErrs.Add(newVar.Key, fmt.Errorf("%s must not be empty", newVar.Key))
+	}
+
 	if len(newVar.Options) > 0 {
 		if !newVar.optionsContains(newVar.Value) {
-			return newVar, fmt.Errorf(`%v="%v" not in allowed options: %v`, newVar.Key, newVar.Value, newVar.Options)
+			fieldErrs.Add(newVar.Key, fmt.Errorf(`%v="%v" not in allowed options: %v`, newVar.Key, newVar.Value, newVar.Options))
+		}
+	}
+
+	if len(newVar.Validators) > 0 {
+		if err := runValidators(newVar.Value, newVar.Validators); err != nil {
+			fieldErrs.Add(newVar.Key, err)
 		}
 	}
 
+	if fieldErrs.HasErrors() {
+		return newVar, fieldErrs
+	}
+
 	return newVar, nil
 }
 
@@ -113,6 +155,41 @@ func (v *Var) SetOptions(values []reflect.Value) {
 	v.Options = values
 }
 
+// SetSeparator sets Var.Separator
+func (v *Var) SetSeparator(value string) {
+	v.Separator = value
+}
+
+// SetNotEmpty sets Var.NotEmpty
+func (v *Var) SetNotEmpty(value bool) {
+	v.NotEmpty = value
+}
+
+// SetPrefix sets Var.Prefix
+func (v *Var) SetPrefix(value string) {
+	v.Prefix = value
+}
+
+// SetValidators sets Var.Validators
+func (v *Var) SetValidators(values []validatorSpec) {
+	v.Validators = values
+}
+
+// SetSensitive sets Var.Sensitive
+func (v *Var) SetSensitive(value bool) {
+	v.Sensitive = value
+}
+
+// SetDesc sets Var.Desc
+func (v *Var) SetDesc(value string) {
+	v.Desc = value
+}
+
+// SetReloadable sets Var.Reloadable
+func (v *Var) SetReloadable(value bool) {
+	v.Reloadable = value
+}
+
 // SetKey sets Var.Key
 func (v *Var) SetKey(value string) {
 	// src := []byte(value)
@@ -129,6 +206,8 @@ func (v *Var) Parse(field reflect.StructField) error {
 	v.SetName(field.Name)
 	v.SetType(field.Type)
 	v.SetKey(v.Name)
+	v.SetDesc(field.Tag.Get("desc"))
+	v.SetReloadable(true)
 
 	tag := field.Tag.Get("env")
 
@@ -136,23 +215,7 @@ func (v *Var) Parse(field reflect.StructField) error {
 		return nil
 	}
 
-	// Use a map so we can process in specific order with lookups
-	// Needed to get the decode param processed first
-	tagParamsMap := make(map[string]string)
-
-	tagParams := strings.Split(tag, " ")
-
-	for _, tagParam := range tagParams {
-		var key, value string
-
-		option := strings.Split(tagParam, "=")
-		key = option[0]
-		if len(option) > 1 {
-			value = option[1]
-		}
-
-		tagParamsMap[key] = value
-	}
+	tagParamsMap := parseTagParams(tag)
 
 	// Process the decode tag
 	// Need to be first so we can decode default / options
@@ -178,7 +241,7 @@ func (v *Var) Parse(field reflect.StructField) error {
 			// set decode strategy
 			v.SetDecode(value)
 		case "default":
-			d, err := convert(v.Type, value, v.Decode)
+			d, err := convert(v.Type, value, v.Decode, v.get)
 			if err != nil {
 				return err
 			}
@@ -188,27 +251,97 @@ func (v *Var) Parse(field reflect.StructField) error {
 			// var values []reflect.Value
 			values := make([]reflect.Value, len(in))
 			for k, val := range in {
-				v1, err := convert(v.Type, val, v.Decode)
+				v1, err := convert(v.Type, val, v.Decode, v.get)
 				if err != nil {
 					return err
 				}
 				values[k] = v1
 			}
 			v.SetOptions(values)
+		case "notEmpty":
+			v.SetNotEmpty(true)
+		case "envSeparator":
+			v.SetSeparator(value)
+		case "prefix":
+			v.SetPrefix(value)
+		case "validate":
+			v.SetValidators(parseValidateTag(value))
+		case "sensitive":
+			v.SetSensitive(true)
+		case "reloadable":
+			v.SetReloadable(value != "false")
 		}
 	}
 
 	return nil
 }
 
+// parseTagParams splits a space-separated `env` tag into a key/value map.
+// Uses a map so callers can process params in a specific order (e.g.
+// "decode" before "default"/"options", which depend on it).
+func parseTagParams(tag string) map[string]string {
+	tagParamsMap := make(map[string]string)
+
+	for _, tagParam := range strings.Split(tag, " ") {
+		// Cut on the first "=" so values that themselves contain "=" (e.g.
+		// "validate=min=1,max=65535") are kept whole.
+		key, value, _ := strings.Cut(tagParam, "=")
+		tagParamsMap[key] = value
+	}
+
+	return tagParamsMap
+}
+
 // Convert a string into the specified type.
 // Return the type's zero value if we receive an empty string
-// Use the decode strategy defined
-func convert(t reflect.Type, value string, decode string) (reflect.Value, error) {
+// Use the decode strategy defined. get resolves variable references for
+// decode strategies (e.g. "expand") that need to look up other keys; it
+// may be nil for strategies that don't use it.
+func convert(t reflect.Type, value string, decode string, get func(string) string) (reflect.Value, error) {
 	if value == "" {
 		return reflect.ValueOf(nil), nil
 	}
 
+	decodes := strings.Split(decode, ",")
+
+	// "expand" runs shell-style expansion on the raw value before the
+	// remaining decode(s) handle type conversion, so it composes with
+	// e.g. "decode=expand,yaml".
+	for i, d := range decodes {
+		if d != "expand" {
+			continue
+		}
+
+		expanded, err := expandShell(value, get)
+		if err != nil {
+			return reflect.ValueOf(nil), err
+		}
+		value = expanded
+
+		decodes = append(decodes[:i], decodes[i+1:]...)
+		break
+	}
+
+	// "secret" treats the raw value as a scheme://... reference and
+	// resolves it via the registered SecretProvider before the remaining
+	// decode(s) handle type conversion.
+	for i, d := range decodes {
+		if d != "secret" {
+			continue
+		}
+
+		resolved, err := resolveSecret(value)
+		if err != nil {
+			return reflect.ValueOf(nil), err
+		}
+		value = resolved
+
+		decodes = append(decodes[:i], decodes[i+1:]...)
+		break
+	}
+
+	decode = strings.Join(decodes, ",")
+
 	switch decode {
 	// if no decode defined, try with type and then kind
 	// if any type is defined then it will be used else fallback to kind
@@ -271,6 +404,50 @@ func convertWithYaml(t reflect.Type, value string) (reflect.Value, error) {
 	return parseYaml(t, value)
 }
 
+// convertWithSeparator splits value on sep to build a slice, or into
+// "key:value" elements (each split on its first ":") to build a map, instead
+// of parsing value as YAML.
+func convertWithSeparator(t reflect.Type, value string, sep string) (reflect.Value, error) {
+	if value == "" {
+		return reflect.ValueOf(nil), nil
+	}
+
+	parts := strings.Split(value, sep)
+
+	switch t.Kind() {
+	case reflect.Slice:
+		slice := reflect.MakeSlice(t, len(parts), len(parts))
+		for i, part := range parts {
+			elem, err := convert(t.Elem(), strings.TrimSpace(part), "", nil)
+			if err != nil {
+				return reflect.ValueOf(nil), err
+			}
+			slice.Index(i).Set(elem)
+		}
+		return slice, nil
+	case reflect.Map:
+		m := reflect.MakeMap(t)
+		for _, part := range parts {
+			kv := strings.SplitN(part, ":", 2)
+			if len(kv) != 2 {
+				return reflect.ValueOf(nil), conversionError(value, `expected "key:value" pairs separated by `+sep)
+			}
+			key, err := convert(t.Key(), strings.TrimSpace(kv[0]), "", nil)
+			if err != nil {
+				return reflect.ValueOf(nil), err
+			}
+			val, err := convert(t.Elem(), strings.TrimSpace(kv[1]), "", nil)
+			if err != nil {
+				return reflect.ValueOf(nil), err
+			}
+			m.SetMapIndex(key, val)
+		}
+		return m, nil
+	}
+
+	return reflect.ValueOf(nil), conversionError(value, `unsupported `+t.Kind().String())
+}
+
 type errConversion struct {
 	Value string
 	Type  string
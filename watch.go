@@ -0,0 +1,293 @@
+package env
+
+import (
+	"log"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	// fsnotify is not in the standard library
+	"github.com/fsnotify/fsnotify"
+)
+
+// OnChangeFunc is invoked after a successful Reload for every field whose
+// resolved value changed, keyed by its composed env key.
+type OnChangeFunc func(key string, old, new interface{})
+
+// pathProvider is implemented by file-backed providers so a Watcher can
+// fsnotify their backing file.
+type pathProvider interface {
+	Path() string
+}
+
+// refreshableProvider is implemented by file-backed providers so a Watcher
+// can have them re-parse their backing file before a reload resolves
+// against them; without this, Reload would just replay the map each
+// provider captured at construction time.
+type refreshableProvider interface {
+	Refresh() error
+}
+
+// Watcher re-runs Load when its underlying sources change and exposes a
+// consistent snapshot of the resolved config via Current, so readers never
+// observe a struct mid-update.
+type Watcher struct {
+	cfgType   reflect.Type
+	providers []Provider
+	current   atomic.Value
+
+	mu        sync.Mutex
+	callbacks []OnChangeFunc
+
+	// reloadMu serializes Reload's refresh/load/merge/store sequence, so
+	// the fsnotify goroutine and a caller-invoked Reload (e.g. from a
+	// SIGHUP handler) can't interleave and drop one set of changes.
+	reloadMu sync.Mutex
+
+	fsWatcher    *fsnotify.Watcher
+	watchedFiles map[string]struct{}
+	done         chan struct{}
+	closeOnce    sync.Once
+}
+
+// NewWatcher loads cfg (a pointer to a struct) via the given providers and
+// returns a Watcher that keeps it current: automatically on changes to any
+// file-backed provider, or on demand via Reload (e.g. from a SIGHUP
+// handler for the OS environment, which can't be watched).
+func NewWatcher(cfg interface{}, providers ...Provider) (*Watcher, error) {
+	if err := Load(cfg, providers...); err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{
+		cfgType:   reflect.TypeOf(cfg).Elem(),
+		providers: providers,
+		done:      make(chan struct{}),
+	}
+	w.current.Store(reflect.ValueOf(cfg).Elem().Interface())
+
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		// No filesystem watch available in this environment; Reload() is
+		// still usable on demand.
+		return w, nil
+	}
+	w.fsWatcher = fsWatcher
+	w.watchedFiles = make(map[string]struct{})
+
+	// Watch each file's parent directory rather than the file itself:
+	// editors and config-management tools commonly "save" by writing a
+	// temp file and renaming it over the target, which replaces the
+	// inode fsnotify would otherwise be watching and silently stops
+	// delivery. Watching the directory and filtering by filename below
+	// survives that rename.
+	watchedDirs := make(map[string]struct{})
+	for _, p := range providers {
+		pp, ok := p.(pathProvider)
+		if !ok || pp.Path() == "" {
+			continue
+		}
+
+		path := filepath.Clean(pp.Path())
+		w.watchedFiles[path] = struct{}{}
+
+		dir := filepath.Dir(path)
+		if _, ok := watchedDirs[dir]; ok {
+			continue
+		}
+
+		if err := fsWatcher.Add(dir); err != nil {
+			log.Printf("env: watch %s: %v", dir, err)
+			continue
+		}
+		watchedDirs[dir] = struct{}{}
+	}
+
+	go w.watch()
+
+	return w, nil
+}
+
+// Current returns a consistent snapshot of the resolved config.
+func (w *Watcher) Current() interface{} {
+	return w.current.Load()
+}
+
+// OnChange registers a callback invoked after every successful Reload, once
+// per field whose resolved value changed.
+func (w *Watcher) OnChange(fn OnChangeFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callbacks = append(w.callbacks, fn)
+}
+
+// Close stops the underlying file watch, if any. Safe to call more than
+// once.
+func (w *Watcher) Close() error {
+	var err error
+
+	w.closeOnce.Do(func() {
+		close(w.done)
+		if w.fsWatcher != nil {
+			err = w.fsWatcher.Close()
+		}
+	})
+
+	return err
+}
+
+func (w *Watcher) watch() {
+	for {
+		select {
+		case <-w.done:
+			return
+		case event, ok := <-w.fsWatcher.Events:
+			if !ok {
+				return
+			}
+			// We watch the parent directory, so filter to events for a
+			// file we actually care about. Create/Rename cover the
+			// write-temp-then-rename-over-target save pattern; Write
+			// covers an in-place rewrite.
+			if _, watched := w.watchedFiles[filepath.Clean(event.Name)]; !watched {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			if err := w.Reload(); err != nil {
+				log.Printf("env: reload after change to %s: %v", event.Name, err)
+			}
+		case err, ok := <-w.fsWatcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("env: watch error: %v", err)
+		}
+	}
+}
+
+// Reload re-parses every file-backed provider, re-resolves the config
+// against them and swaps it in atomically. Fields tagged
+// `env:"reloadable=false"` that changed keep their previous value (and log
+// a warning) instead of being applied. Registered OnChange callbacks run
+// for every field that did change. Reload is safe to call concurrently
+// with itself (e.g. a SIGHUP handler racing the fsnotify-driven reload).
+func (w *Watcher) Reload() error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	for _, p := range w.providers {
+		if rp, ok := p.(refreshableProvider); ok {
+			if err := rp.Refresh(); err != nil {
+				return err
+			}
+		}
+	}
+
+	newCfgPtr := reflect.New(w.cfgType)
+	if err := Load(newCfgPtr.Interface(), w.providers...); err != nil {
+		return err
+	}
+
+	oldValue := reflect.ValueOf(w.current.Load())
+	newValue := newCfgPtr.Elem()
+
+	merged := reflect.New(w.cfgType).Elem()
+	merged.Set(oldValue)
+
+	var changes []fieldChange
+	mergeReload(merged, oldValue, newValue, "", &changes)
+
+	w.current.Store(merged.Interface())
+
+	w.mu.Lock()
+	callbacks := make([]OnChangeFunc, len(w.callbacks))
+	copy(callbacks, w.callbacks)
+	w.mu.Unlock()
+
+	for _, change := range changes {
+		for _, cb := range callbacks {
+			cb(change.key, change.old, change.new)
+		}
+	}
+
+	return nil
+}
+
+type fieldChange struct {
+	key string
+	old interface{}
+	new interface{}
+}
+
+// mergeReload walks old/new/merged in lockstep, keeping merged's existing
+// value for any field tagged reloadable=false whose resolved value
+// changed, and otherwise copying new's value into merged and recording a
+// fieldChange for OnChange callbacks.
+func mergeReload(merged, old, new reflect.Value, keyPrefix string, changes *[]fieldChange) {
+	structType := merged.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		mergedField := merged.Field(i)
+		oldField := old.Field(i)
+		newField := new.Field(i)
+
+		params := parseTagParams(field.Tag.Get("env"))
+
+		fieldType := field.Type
+		isPtr := fieldType.Kind() == reflect.Ptr
+		if isPtr {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType.Kind() == reflect.Struct && fieldType != timeTimeType && params["decode"] == "" {
+			childPrefix := nestedKeyPrefix(keyPrefix, field, params)
+
+			mergedNested, oldNested, newNested := mergedField, oldField, newField
+
+			if isPtr {
+				if newNested.IsNil() {
+					continue
+				}
+				if oldNested.IsNil() {
+					oldNested = reflect.New(fieldType).Elem()
+				} else {
+					oldNested = oldNested.Elem()
+				}
+				// Give merged its own copy of the pointed-to struct so the
+				// previous snapshot is never mutated in place.
+				fresh := reflect.New(fieldType)
+				fresh.Elem().Set(oldNested)
+				mergedNested.Set(fresh)
+				mergedNested = fresh.Elem()
+				newNested = newNested.Elem()
+			}
+
+			mergeReload(mergedNested, oldNested, newNested, childPrefix, changes)
+			continue
+		}
+
+		if reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
+			continue
+		}
+
+		newVar := &Var{}
+		newVar.Parse(field)
+		key := keyPrefix + newVar.Key
+
+		if !newVar.Reloadable {
+			log.Printf("env: %s changed but is not reloadable; keeping previous value", key)
+			continue
+		}
+
+		mergedField.Set(newField)
+		*changes = append(*changes, fieldChange{key: key, old: oldField.Interface(), new: newField.Interface()})
+	}
+}
@@ -0,0 +1,183 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcherReloadPicksUpFileChanges(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+
+	if err := os.WriteFile(path, []byte("HOST=localhost\nPORT=8080\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	provider, err := NewDotenvProvider(path)
+	if err != nil {
+		t.Fatalf("NewDotenvProvider: %v", err)
+	}
+
+	type config struct {
+		Host string `env:"key=HOST"`
+		Port int    `env:"key=PORT"`
+	}
+
+	var cfg config
+	w, err := NewWatcher(&cfg, provider)
+	if err != nil {
+		t.Fatalf("NewWatcher: %v", err)
+	}
+	defer w.Close()
+
+	current := w.Current().(config)
+	if current.Host != "localhost" || current.Port != 8080 {
+		t.Fatalf("initial Current() = %+v, want Host=localhost Port=8080", current)
+	}
+
+	if err := os.WriteFile(path, []byte("HOST=example.com\nPORT=9090\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := w.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	current = w.Current().(config)
+	if current.Host != "example.com" || current.Port != 9090 {
+		t.Fatalf("Current() after Reload = %+v, want Host=example.com Port=9090", current)
+	}
+}
+
+func TestWatcherReloadKeepsNonReloadableFields(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+
+	if err := os.WriteFile(path, []byte("HOST=localhost\nPORT=8080\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	provider, err := NewDotenvProvider(path)
+	if err != nil {
+		t.Fatalf("NewDotenvProvider: %v", err)
+	}
+
+	type config struct {
+		Host string `env:"key=HOST reloadable=false"`
+		Port int    `env:"key=PORT"`
+	}
+
+	var cfg config
+	w, err := NewWatcher(&cfg, provider)
+	if err != nil {
+		t.Fatalf("NewWatcher: %v", err)
+	}
+	defer w.Close()
+
+	if err := os.WriteFile(path, []byte("HOST=example.com\nPORT=9090\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := w.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	current := w.Current().(config)
+	if current.Host != "localhost" {
+		t.Errorf("Host = %q, want unchanged %q (reloadable=false)", current.Host, "localhost")
+	}
+	if current.Port != 9090 {
+		t.Errorf("Port = %d, want reloaded %d", current.Port, 9090)
+	}
+}
+
+func TestWatcherOnChangeFiresForChangedFields(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+
+	if err := os.WriteFile(path, []byte("HOST=localhost\nPORT=8080\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	provider, err := NewDotenvProvider(path)
+	if err != nil {
+		t.Fatalf("NewDotenvProvider: %v", err)
+	}
+
+	type config struct {
+		Host string `env:"key=HOST"`
+		Port int    `env:"key=PORT"`
+	}
+
+	var cfg config
+	w, err := NewWatcher(&cfg, provider)
+	if err != nil {
+		t.Fatalf("NewWatcher: %v", err)
+	}
+	defer w.Close()
+
+	changed := make(map[string]interface{})
+	w.OnChange(func(key string, old, new interface{}) {
+		changed[key] = new
+	})
+
+	if err := os.WriteFile(path, []byte("HOST=example.com\nPORT=8080\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := w.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	if got, ok := changed["HOST"]; !ok || got != "example.com" {
+		t.Errorf("OnChange callback for HOST = %v, ok=%v, want example.com, true", got, ok)
+	}
+	if _, ok := changed["PORT"]; ok {
+		t.Errorf("OnChange callback fired for unchanged PORT")
+	}
+}
+
+func TestWatcherReloadsOnFileWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+
+	if err := os.WriteFile(path, []byte("HOST=localhost\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	provider, err := NewDotenvProvider(path)
+	if err != nil {
+		t.Fatalf("NewDotenvProvider: %v", err)
+	}
+
+	type config struct {
+		Host string `env:"key=HOST"`
+	}
+
+	var cfg config
+	w, err := NewWatcher(&cfg, provider)
+	if err != nil {
+		t.Fatalf("NewWatcher: %v", err)
+	}
+	defer w.Close()
+
+	if w.fsWatcher == nil {
+		t.Skip("no filesystem watch available in this environment")
+	}
+
+	if err := os.WriteFile(path, []byte("HOST=example.com\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if w.Current().(config).Host == "example.com" {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	t.Fatalf("Current().Host = %q after file write, want %q", w.Current().(config).Host, "example.com")
+}